@@ -0,0 +1,11 @@
+package main
+
+import "time"
+
+// RateCache caches a raw upstream JSON payload under an implementation
+// defined key (e.g. a base currency for latest rates, or a date range for
+// historical rates).
+type RateCache interface {
+	Get(key string) (data []byte, fetchedAt time.Time, ok bool)
+	Set(key string, data []byte, fetchedAt time.Time)
+}
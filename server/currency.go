@@ -0,0 +1,13 @@
+package main
+
+import "regexp"
+
+var isoCurrencyPattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+// isValidCurrencyCode reports whether code is syntactically a valid ISO 4217
+// alphabetic currency code. It does not check that the currency is actually
+// supported upstream; callers should cross-check against a Frankfurter
+// response for that.
+func isValidCurrencyCode(code string) bool {
+	return isoCurrencyPattern.MatchString(code)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const exchangerateHostBaseURL = "https://api.exchangerate.host/latest"
+
+// exchangerateHostProvider fetches latest rates from exchangerate.host, used
+// as an alternate source to Frankfurter so the service is not single-sourced.
+type exchangerateHostProvider struct{}
+
+func (exchangerateHostProvider) Name() string { return "exchangerate.host" }
+
+func (p exchangerateHostProvider) Fetch(ctx context.Context, base string) (*Rates, time.Time, error) {
+	start := time.Now()
+	data, err := fetchJSON(ctx, exchangerateHostBaseURL+"?base="+base)
+	upstreamFetchDuration.WithLabelValues(p.Name()).Observe(time.Since(start).Seconds())
+	if err != nil {
+		upstreamFetchErrorsTotal.WithLabelValues(p.Name(), upstreamStatusLabel(err)).Inc()
+		return nil, time.Time{}, err
+	}
+
+	var rates Rates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, time.Time{}, err
+	}
+	if !strings.EqualFold(rates.Base, base) {
+		return nil, time.Time{}, errors.New("unexpected base currency in response")
+	}
+	return &rates, time.Now(), nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// frankfurterProvider fetches latest rates from Frankfurter.
+type frankfurterProvider struct{}
+
+func (frankfurterProvider) Name() string { return "frankfurter" }
+
+func (p frankfurterProvider) Fetch(ctx context.Context, base string) (*Rates, time.Time, error) {
+	start := time.Now()
+	data, err := fetchJSON(ctx, frankfurterBaseURL+"/latest?base="+base)
+	upstreamFetchDuration.WithLabelValues(p.Name()).Observe(time.Since(start).Seconds())
+	if err != nil {
+		upstreamFetchErrorsTotal.WithLabelValues(p.Name(), upstreamStatusLabel(err)).Inc()
+		return nil, time.Time{}, err
+	}
+
+	var rates Rates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, time.Time{}, err
+	}
+	if !strings.EqualFold(rates.Base, base) {
+		return nil, time.Time{}, errors.New("unexpected base currency in response")
+	}
+	return &rates, time.Now(), nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var defaultTargetCurrencies = []string{"CNY", "SEK", "EUR"}
+
+type apiResponse struct {
+	Base      string             `json:"base"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+type convertResponse struct {
+	Base      string             `json:"base"`
+	Amount    float64            `json:"amount"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Rates     map[string]float64 `json:"rates"`
+	Converted map[string]float64 `json:"converted"`
+}
+
+func handleAllRate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	start := time.Now()
+	payload, fetchedAt, stale, err := getRates(r.Context(), "USD")
+	if err != nil {
+		logger.Error("failed to get rates", "base", "USD", "error", err)
+		http.Error(w, "failed to fetch rates", http.StatusBadGateway)
+		return
+	}
+	logger.Info("served all-rate", "base", "USD", "latency_ms", time.Since(start).Milliseconds(), "stale", stale)
+	if stale {
+		w.Header().Set("X-Cache-Status", "stale")
+	}
+
+	response := apiResponse{
+		Base:      "USD",
+		UpdatedAt: fetchedAt.UTC(),
+		Rates:     filterRates(payload, defaultTargetCurrencies),
+	}
+
+	writeJSON(w, response)
+}
+
+func handleConvert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+
+	base := strings.ToUpper(strings.TrimSpace(query.Get("from")))
+	if base == "" {
+		base = "USD"
+	}
+	if !isValidCurrencyCode(base) {
+		http.Error(w, "invalid from currency code", http.StatusBadRequest)
+		return
+	}
+	// Unlike the to codes below, base is not cross-checked against a live
+	// Frankfurter payload here: doing so would mean fetching rates before we
+	// can validate, which is exactly the call a few lines down. A
+	// syntactically-valid but upstream-unsupported base (e.g. a retired
+	// code) therefore surfaces as a 502 from the getRates call below rather
+	// than a 400.
+
+	toParam := strings.TrimSpace(query.Get("to"))
+	if toParam == "" {
+		http.Error(w, "to is required", http.StatusBadRequest)
+		return
+	}
+	targets := strings.Split(toParam, ",")
+	for i, code := range targets {
+		targets[i] = strings.ToUpper(strings.TrimSpace(code))
+		if !isValidCurrencyCode(targets[i]) {
+			http.Error(w, "invalid to currency code: "+targets[i], http.StatusBadRequest)
+			return
+		}
+	}
+
+	amount := 1.0
+	if amountParam := query.Get("amount"); amountParam != "" {
+		parsed, err := strconv.ParseFloat(amountParam, 64)
+		if err != nil || parsed < 0 || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+			http.Error(w, "invalid amount", http.StatusBadRequest)
+			return
+		}
+		amount = parsed
+	}
+
+	payload, fetchedAt, stale, err := getRates(r.Context(), base)
+	if err != nil {
+		logger.Error("failed to get rates", "base", base, "error", err)
+		http.Error(w, "failed to fetch rates", http.StatusBadGateway)
+		return
+	}
+	if stale {
+		w.Header().Set("X-Cache-Status", "stale")
+	}
+
+	if unknown := unknownCodes(payload, targets); len(unknown) > 0 {
+		http.Error(w, "unknown currency code(s): "+strings.Join(unknown, ", "), http.StatusBadRequest)
+		return
+	}
+
+	rates := filterRates(payload, targets)
+	converted := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		converted[code] = rate * amount
+	}
+
+	response := convertResponse{
+		Base:      base,
+		Amount:    amount,
+		UpdatedAt: fetchedAt.UTC(),
+		Rates:     rates,
+		Converted: converted,
+	}
+
+	writeJSON(w, response)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Error("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// withStubRates points rateProvider and latestCache at a stub that always
+// serves rates for the duration of the test, restoring both afterwards.
+func withStubRates(t *testing.T, rates *Rates) {
+	t.Helper()
+	origProvider := rateProvider
+	origCache := latestCache
+	t.Cleanup(func() {
+		rateProvider = origProvider
+		latestCache = origCache
+	})
+
+	latestCache = NewLatestCache()
+	rateProvider = &stubProvider{name: "stub", rates: rates}
+}
+
+func TestHandleConvertUnknownCurrencyCode(t *testing.T) {
+	withStubRates(t, &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.1}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/convert?from=USD&to=XXX", nil)
+	handleConvert(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestHandleConvertRejectsNonFiniteOrNegativeAmount(t *testing.T) {
+	withStubRates(t, &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.1}})
+
+	for _, amount := range []string{"NaN", "Inf", "+Inf", "-Inf", "Infinity", "-1"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/convert?from=USD&to=EUR&amount="+amount, nil)
+		handleConvert(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("amount=%q: status = %d, want %d", amount, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHandleConvertSelfBaseConversion(t *testing.T) {
+	withStubRates(t, &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.1}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/convert?from=USD&to=USD,EUR&amount=100", nil)
+	handleConvert(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp convertResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Rates["USD"] != 1.0 {
+		t.Errorf("Rates[USD] = %v, want 1.0", resp.Rates["USD"])
+	}
+	if resp.Converted["USD"] != 100 {
+		t.Errorf("Converted[USD] = %v, want 100", resp.Converted["USD"])
+	}
+}
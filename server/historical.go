@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// historicalCacheDir is where HistoricalCache persists immutable
+// historical-rate responses, overridable via the HISTORICAL_CACHE_DIR env
+// var.
+var historicalCacheDir = envOrDefault("HISTORICAL_CACHE_DIR", "./cache/historical")
+
+var historicalCache RateCache = NewHistoricalCache(historicalCacheDir)
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// HistoricalCache permanently caches immutable Frankfurter historical and
+// time-series responses, backed by one JSON file per key under dir.
+type HistoricalCache struct {
+	mu  sync.RWMutex
+	dir string
+	mem map[string][]byte
+}
+
+func NewHistoricalCache(dir string) *HistoricalCache {
+	return &HistoricalCache{dir: dir, mem: make(map[string][]byte)}
+}
+
+func (c *HistoricalCache) Get(key string) ([]byte, time.Time, bool) {
+	c.mu.RLock()
+	if data, ok := c.mem[key]; ok {
+		c.mu.RUnlock()
+		cacheResultsTotal.WithLabelValues("historical", "hit").Inc()
+		return data, time.Time{}, true
+	}
+	c.mu.RUnlock()
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		cacheResultsTotal.WithLabelValues("historical", "miss").Inc()
+		return nil, time.Time{}, false
+	}
+
+	c.mu.Lock()
+	c.mem[key] = data
+	c.mu.Unlock()
+	cacheResultsTotal.WithLabelValues("historical", "hit").Inc()
+	return data, time.Time{}, true
+}
+
+func (c *HistoricalCache) Set(key string, data []byte, _ time.Time) {
+	c.mu.Lock()
+	c.mem[key] = data
+	c.mu.Unlock()
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		logger.Error("failed to create historical cache dir", "dir", c.dir, "error", err)
+		return
+	}
+	if err := os.WriteFile(c.path(key), data, 0o644); err != nil {
+		logger.Error("failed to persist historical cache entry", "key", key, "error", err)
+	}
+}
+
+func (c *HistoricalCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+var dateParamPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+type timeseriesResponse struct {
+	Base      string                        `json:"base"`
+	StartDate string                        `json:"start_date"`
+	EndDate   string                        `json:"end_date"`
+	Rates     map[string]map[string]float64 `json:"rates"`
+}
+
+func handleHistorical(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	date := strings.TrimSpace(query.Get("date"))
+	if !dateParamPattern.MatchString(date) {
+		http.Error(w, "date must be in YYYY-MM-DD format", http.StatusBadRequest)
+		return
+	}
+
+	base := strings.ToUpper(strings.TrimSpace(query.Get("base")))
+	if base == "" {
+		base = "USD"
+	}
+	if !isValidCurrencyCode(base) {
+		http.Error(w, "invalid base currency code", http.StatusBadRequest)
+		return
+	}
+
+	key := base + "_" + date
+	url := frankfurterBaseURL + "/" + date + "?base=" + base
+	data, _, err := fetchAndCache(r.Context(), historicalCache, key, url)
+	if err != nil {
+		logger.Error("failed to get historical rates", "key", key, "error", err)
+		http.Error(w, "failed to fetch historical rates", http.StatusBadGateway)
+		return
+	}
+
+	payload, err := decodeFrankfurterResponse(data, base)
+	if err != nil {
+		http.Error(w, "unexpected upstream response", http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, payload)
+}
+
+func handleTimeseries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	from := strings.TrimSpace(query.Get("from"))
+	to := strings.TrimSpace(query.Get("to"))
+	if !dateParamPattern.MatchString(from) || !dateParamPattern.MatchString(to) {
+		http.Error(w, "from and to must be in YYYY-MM-DD format", http.StatusBadRequest)
+		return
+	}
+
+	base := strings.ToUpper(strings.TrimSpace(query.Get("base")))
+	if base == "" {
+		base = "USD"
+	}
+	if !isValidCurrencyCode(base) {
+		http.Error(w, "invalid base currency code", http.StatusBadRequest)
+		return
+	}
+
+	key := base + "_" + from + "_" + to
+	url := frankfurterBaseURL + "/" + from + ".." + to + "?base=" + base
+	data, _, err := fetchAndCache(r.Context(), historicalCache, key, url)
+	if err != nil {
+		logger.Error("failed to get timeseries rates", "key", key, "error", err)
+		http.Error(w, "failed to fetch timeseries rates", http.StatusBadGateway)
+		return
+	}
+
+	var payload timeseriesResponse
+	if err := json.Unmarshal(data, &payload); err != nil {
+		http.Error(w, "unexpected upstream response", http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, payload)
+}
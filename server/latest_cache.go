@@ -0,0 +1,53 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// LatestCache is an in-memory RateCache for "latest rates" lookups. Each
+// entry is valid for cacheTTL before it must be refetched from upstream.
+type LatestCache struct {
+	mu      sync.RWMutex
+	entries map[string]latestCacheEntry
+}
+
+type latestCacheEntry struct {
+	data      []byte
+	fetchedAt time.Time
+}
+
+func NewLatestCache() *LatestCache {
+	return &LatestCache{entries: make(map[string]latestCacheEntry)}
+}
+
+func (c *LatestCache) Get(key string) ([]byte, time.Time, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok || time.Since(entry.fetchedAt) >= cacheTTL {
+		cacheResultsTotal.WithLabelValues("latest", "miss").Inc()
+		return nil, time.Time{}, false
+	}
+	cacheResultsTotal.WithLabelValues("latest", "hit").Inc()
+	return entry.data, entry.fetchedAt, true
+}
+
+func (c *LatestCache) Set(key string, data []byte, fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = latestCacheEntry{data: data, fetchedAt: fetchedAt}
+}
+
+// GetStale returns the last known entry for key regardless of cacheTTL, for
+// use as a stale-while-revalidate fallback when an upstream refresh fails.
+func (c *LatestCache) GetStale(key string) ([]byte, time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	return entry.data, entry.fetchedAt, true
+}
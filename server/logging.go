@@ -0,0 +1,11 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// logger is the service-wide structured logger. Fields such as base,
+// latency_ms, cache_hit, and upstream_status should be attached at each call
+// site so they remain queryable.
+var logger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MedianProvider fetches from all underlying providers concurrently and
+// returns, per currency, the median of the rates that succeeded. This
+// smooths over one bad feed without requiring every provider to agree.
+type MedianProvider struct {
+	providers []RateProvider
+}
+
+func NewMedianProvider(providers ...RateProvider) *MedianProvider {
+	return &MedianProvider{providers: providers}
+}
+
+func (m *MedianProvider) Name() string { return "median" }
+
+func (m *MedianProvider) Fetch(ctx context.Context, base string) (*Rates, time.Time, error) {
+	type result struct {
+		rates *Rates
+		err   error
+	}
+
+	results := make([]result, len(m.providers))
+	var wg sync.WaitGroup
+	for i, p := range m.providers {
+		wg.Add(1)
+		go func(i int, p RateProvider) {
+			defer wg.Done()
+			rates, _, err := p.Fetch(ctx, base)
+			if err != nil {
+				logger.Warn("rate provider failed, excluding from median", "provider", p.Name(), "error", err)
+			}
+			results[i] = result{rates: rates, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+
+	perCurrency := make(map[string][]float64)
+	var date string
+	succeeded := 0
+	for _, r := range results {
+		if r.err != nil || r.rates == nil {
+			continue
+		}
+		succeeded++
+		date = r.rates.Date
+		for code, rate := range r.rates.Rates {
+			perCurrency[code] = append(perCurrency[code], rate)
+		}
+	}
+	if succeeded == 0 {
+		return nil, time.Time{}, errors.New("all rate providers failed")
+	}
+
+	merged := make(map[string]float64, len(perCurrency))
+	for code, values := range perCurrency {
+		merged[code] = median(values)
+	}
+
+	return &Rates{Base: base, Date: date, Rates: merged}, time.Now(), nil
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// stubProvider is a RateProvider test double that returns a fixed result (or
+// error) and records how many times Fetch was called.
+type stubProvider struct {
+	name  string
+	rates *Rates
+	err   error
+	calls atomic.Int32
+}
+
+func (s *stubProvider) Name() string { return s.name }
+
+func (s *stubProvider) Fetch(ctx context.Context, base string) (*Rates, time.Time, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return nil, time.Time{}, s.err
+	}
+	return s.rates, time.Now(), nil
+}
+
+func TestMedianOddLength(t *testing.T) {
+	if got := median([]float64{3, 1, 2}); got != 2 {
+		t.Errorf("median(3, 1, 2) = %v, want 2", got)
+	}
+}
+
+func TestMedianEvenLength(t *testing.T) {
+	if got := median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("median(4, 1, 3, 2) = %v, want 2.5", got)
+	}
+}
+
+func TestMedianProviderFetch(t *testing.T) {
+	a := &stubProvider{name: "a", rates: &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.0, "CNY": 7.0}}}
+	b := &stubProvider{name: "b", rates: &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.2}}}
+	c := &stubProvider{name: "c", rates: &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.1, "CNY": 7.2}}}
+
+	rates, _, err := NewMedianProvider(a, b, c).Fetch(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rates.Rates["EUR"]; got != 1.1 {
+		t.Errorf("EUR median (3 values) = %v, want 1.1", got)
+	}
+	if got := rates.Rates["CNY"]; got != 7.1 {
+		t.Errorf("CNY median (2 values, only a and c report it) = %v, want 7.1", got)
+	}
+}
+
+func TestMedianProviderAllFail(t *testing.T) {
+	a := &stubProvider{name: "a", err: errors.New("boom")}
+	b := &stubProvider{name: "b", err: errors.New("boom")}
+
+	if _, _, err := NewMedianProvider(a, b).Fetch(context.Background(), "USD"); err == nil {
+		t.Fatal("expected an error when every provider fails")
+	}
+}
+
+func TestMedianProviderPartialFailure(t *testing.T) {
+	a := &stubProvider{name: "a", err: errors.New("boom")}
+	b := &stubProvider{name: "b", rates: &Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.2}}}
+
+	rates, _, err := NewMedianProvider(a, b).Fetch(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := rates.Rates["EUR"]; got != 1.2 {
+		t.Errorf("EUR = %v, want 1.2 from the sole surviving provider", got)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	upstreamFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lc_exchanger_upstream_fetch_duration_seconds",
+		Help:    "Latency of upstream rate-provider fetches.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"provider"})
+
+	upstreamFetchErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "lc_exchanger_upstream_fetch_errors_total",
+		Help: "Count of failed upstream fetches, by provider and status.",
+	}, []string{"provider", "status"})
+
+	cacheResultsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "lc_exchanger_cache_results_total",
+		Help: "Count of cache lookups, by cache and hit/miss result.",
+	}, []string{"cache", "result"})
+
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lc_exchanger_request_duration_seconds",
+		Help:    "Latency of HTTP requests, by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+)
+
+func metricsHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// withMetrics records request duration for endpoint and delegates to next.
+func withMetrics(endpoint string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next(w, r)
+		requestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"time"
+)
+
+// MultiProvider tries each underlying provider in order and returns the
+// first successful result, so a single upstream outage does not take down
+// rate lookups.
+type MultiProvider struct {
+	providers []RateProvider
+}
+
+func NewMultiProvider(providers ...RateProvider) *MultiProvider {
+	return &MultiProvider{providers: providers}
+}
+
+func (m *MultiProvider) Name() string { return "multi" }
+
+func (m *MultiProvider) Fetch(ctx context.Context, base string) (*Rates, time.Time, error) {
+	var lastErr error
+	for _, p := range m.providers {
+		rates, fetchedAt, err := p.Fetch(ctx, base)
+		if err == nil {
+			return rates, fetchedAt, nil
+		}
+		logger.Warn("rate provider failed, trying next", "provider", p.Name(), "error", err)
+		lastErr = err
+	}
+	return nil, time.Time{}, lastErr
+}
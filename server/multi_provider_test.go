@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMultiProviderReturnsFirstSuccess(t *testing.T) {
+	first := &stubProvider{name: "first", rates: &Rates{Base: "USD", Rates: map[string]float64{"EUR": 1.0}}}
+	second := &stubProvider{name: "second", rates: &Rates{Base: "USD", Rates: map[string]float64{"EUR": 2.0}}}
+
+	rates, _, err := NewMultiProvider(first, second).Fetch(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rates.Rates["EUR"] != 1.0 {
+		t.Errorf("EUR = %v, want the first provider's 1.0", rates.Rates["EUR"])
+	}
+	if second.calls.Load() != 0 {
+		t.Errorf("second provider should not be called once the first succeeds, got %d calls", second.calls.Load())
+	}
+}
+
+func TestMultiProviderFallsBackOnFailure(t *testing.T) {
+	first := &stubProvider{name: "first", err: errors.New("down")}
+	second := &stubProvider{name: "second", rates: &Rates{Base: "USD", Rates: map[string]float64{"EUR": 2.0}}}
+
+	rates, _, err := NewMultiProvider(first, second).Fetch(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rates.Rates["EUR"] != 2.0 {
+		t.Errorf("EUR = %v, want the second provider's 2.0", rates.Rates["EUR"])
+	}
+}
+
+func TestMultiProviderAllFail(t *testing.T) {
+	first := &stubProvider{name: "first", err: errors.New("down")}
+	second := &stubProvider{name: "second", err: errors.New("also down")}
+
+	if _, _, err := NewMultiProvider(first, second).Fetch(context.Background(), "USD"); err == nil {
+		t.Fatal("expected an error when every provider fails")
+	}
+}
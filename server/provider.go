@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Rates is a provider-agnostic snapshot of exchange rates relative to Base.
+type Rates struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// RateProvider fetches the latest exchange rates for base from some
+// upstream source.
+type RateProvider interface {
+	Name() string
+	Fetch(ctx context.Context, base string) (*Rates, time.Time, error)
+}
+
+var allProviders = map[string]RateProvider{
+	"frankfurter":       frankfurterProvider{},
+	"exchangerate.host": exchangerateHostProvider{},
+}
+
+// buildRateProvider assembles the RateProvider used by getRates from the
+// RATE_PROVIDERS (comma-separated provider names, in order) and
+// RATE_PROVIDER_STRATEGY ("multi" for first-success failover, "median" to
+// fetch all concurrently and take the per-currency median) env vars.
+func buildRateProvider() RateProvider {
+	var providers []RateProvider
+	for _, name := range strings.Split(envOrDefault("RATE_PROVIDERS", "frankfurter,exchangerate.host"), ",") {
+		if p, ok := allProviders[strings.TrimSpace(name)]; ok {
+			providers = append(providers, p)
+		}
+	}
+	if len(providers) == 0 {
+		providers = []RateProvider{frankfurterProvider{}}
+	}
+
+	if envOrDefault("RATE_PROVIDER_STRATEGY", "multi") == "median" {
+		return NewMedianProvider(providers...)
+	}
+	return NewMultiProvider(providers...)
+}
+
+var rateProvider = buildRateProvider()
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const frankfurterBaseURL = "https://api.frankfurter.dev/v1"
+
+var httpClient = &http.Client{Timeout: 8 * time.Second}
+
+type frankfurterResponse struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+var latestCache = NewLatestCache()
+
+// fetchGroup collapses concurrent upstream fetches for the same base
+// currency into a single Frankfurter call.
+var fetchGroup singleflight.Group
+
+type fetchResult struct {
+	data      []byte
+	fetchedAt time.Time
+}
+
+// getRates returns the latest rates for base, using latestCache when it is
+// still fresh and falling back to rateProvider on a miss. Concurrent misses
+// for the same base collapse into one upstream call via fetchGroup. If that
+// call fails, the last known payload for base is served instead (with
+// stale=true) rather than failing the request outright. Note that err is
+// nil in this stale-serve case even though the underlying upstream fetch
+// failed — callers that need to distinguish a genuine upstream success from
+// a masked failure must check stale, not just err.
+func getRates(ctx context.Context, base string) (payload *frankfurterResponse, fetchedAt time.Time, stale bool, err error) {
+	if data, cachedAt, ok := latestCache.Get(base); ok {
+		payload, err = decodeRates(data)
+		return payload, cachedAt, false, err
+	}
+
+	v, fetchErr, _ := fetchGroup.Do(base, func() (interface{}, error) {
+		rates, _, err := rateProvider.Fetch(ctx, base)
+		readiness.recordFetch(err == nil)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(rates)
+		if err != nil {
+			return nil, err
+		}
+		fetchedAt := time.Now()
+		latestCache.Set(base, data, fetchedAt)
+		return fetchResult{data: data, fetchedAt: fetchedAt}, nil
+	})
+
+	if fetchErr != nil {
+		if staleData, staleAt, ok := latestCache.GetStale(base); ok {
+			logger.Warn("upstream fetch failed, serving stale cache",
+				"base", base, "error", fetchErr, "fetched_at", staleAt)
+			payload, err = decodeRates(staleData)
+			return payload, staleAt, true, err
+		}
+		return nil, time.Time{}, false, fetchErr
+	}
+
+	result := v.(fetchResult)
+	payload, err = decodeRates(result.data)
+	return payload, result.fetchedAt, false, err
+}
+
+// decodeRates unmarshals a cached Rates payload into the frankfurterResponse
+// shape the rest of the package (filterRates, unknownCodes, handlers) works
+// with, regardless of which provider originally produced it.
+func decodeRates(data []byte) (*frankfurterResponse, error) {
+	var rates Rates
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, err
+	}
+	return &frankfurterResponse{Base: rates.Base, Date: rates.Date, Rates: rates.Rates}, nil
+}
+
+// fetchAndCache serves key from c when present, otherwise fetches url from
+// upstream and stores the raw response in c before returning it.
+func fetchAndCache(ctx context.Context, c RateCache, key, url string) ([]byte, time.Time, error) {
+	if data, fetchedAt, ok := c.Get(key); ok {
+		return data, fetchedAt, nil
+	}
+
+	data, err := fetchJSON(ctx, url)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	fetchedAt := time.Now()
+	c.Set(key, data, fetchedAt)
+	return data, fetchedAt, nil
+}
+
+// upstreamStatusError is returned by fetchJSON when the upstream API
+// responds with a non-200 status, so callers can report the status code
+// without parsing error strings.
+type upstreamStatusError struct {
+	status int
+}
+
+func (e *upstreamStatusError) Error() string {
+	return "frankfurter responded with status " + strconv.Itoa(e.status)
+}
+
+// upstreamStatusLabel returns a Prometheus-friendly label for an error
+// returned by fetchJSON: the upstream status code if there is one,
+// otherwise "network_error".
+func upstreamStatusLabel(err error) string {
+	var statusErr *upstreamStatusError
+	if errors.As(err, &statusErr) {
+		return strconv.Itoa(statusErr.status)
+	}
+	return "network_error"
+}
+
+func fetchJSON(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &upstreamStatusError{status: resp.StatusCode}
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func decodeFrankfurterResponse(data []byte, base string) (*frankfurterResponse, error) {
+	var payload frankfurterResponse
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(payload.Base, base) {
+		return nil, errors.New("unexpected base currency in response")
+	}
+	return &payload, nil
+}
+
+// filterRates extracts only the requested target codes from a payload,
+// skipping any that Frankfurter did not return. Frankfurter's rates map never
+// includes the base currency itself (its rate to itself is implicitly 1), so
+// that case is special-cased here rather than silently dropped.
+func filterRates(payload *frankfurterResponse, targets []string) map[string]float64 {
+	result := make(map[string]float64, len(targets))
+	for _, code := range targets {
+		if strings.EqualFold(code, payload.Base) {
+			result[code] = 1.0
+			continue
+		}
+		if value, ok := payload.Rates[code]; ok {
+			result[code] = value
+		}
+	}
+	return result
+}
+
+// unknownCodes returns the subset of codes that are neither the payload's
+// base currency nor present in its rates map.
+func unknownCodes(payload *frankfurterResponse, codes []string) []string {
+	var unknown []string
+	for _, code := range codes {
+		if strings.EqualFold(code, payload.Base) {
+			continue
+		}
+		if _, ok := payload.Rates[code]; !ok {
+			unknown = append(unknown, code)
+		}
+	}
+	return unknown
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGetRatesFallsBackToStaleOnUpstreamFailure(t *testing.T) {
+	origProvider := rateProvider
+	origCache := latestCache
+	t.Cleanup(func() {
+		rateProvider = origProvider
+		latestCache = origCache
+	})
+
+	latestCache = NewLatestCache()
+	staleData, err := json.Marshal(Rates{Base: "USD", Date: "2024-01-01", Rates: map[string]float64{"EUR": 1.1}})
+	if err != nil {
+		t.Fatalf("marshal stale fixture: %v", err)
+	}
+	latestCache.Set("USD", staleData, time.Now().Add(-2*cacheTTL))
+
+	rateProvider = &stubProvider{name: "down", err: errors.New("upstream down")}
+
+	payload, _, stale, err := getRates(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !stale {
+		t.Fatal("expected stale=true when upstream fails but a cached entry exists")
+	}
+	if got := payload.Rates["EUR"]; got != 1.1 {
+		t.Errorf("EUR = %v, want 1.1 from the stale cache entry", got)
+	}
+}
+
+func TestGetRatesFailsWithNoCacheAndUpstreamDown(t *testing.T) {
+	origProvider := rateProvider
+	origCache := latestCache
+	t.Cleanup(func() {
+		rateProvider = origProvider
+		latestCache = origCache
+	})
+
+	latestCache = NewLatestCache()
+	rateProvider = &stubProvider{name: "down", err: errors.New("upstream down")}
+
+	if _, _, _, err := getRates(context.Background(), "USD"); err == nil {
+		t.Fatal("expected an error when upstream fails and there is no cached fallback")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+)
+
+// readiness tracks whether the rate cache has ever been successfully
+// populated and whether the most recent upstream fetch attempt succeeded,
+// for use by /readyz.
+var readiness = &readinessState{}
+
+type readinessState struct {
+	mu            sync.RWMutex
+	everPopulated bool
+	lastFetchOK   bool
+}
+
+func (r *readinessState) recordFetch(ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastFetchOK = ok
+	if ok {
+		r.everPopulated = true
+	}
+}
+
+func (r *readinessState) ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.everPopulated || r.lastFetchOK
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !readiness.ready() {
+		http.Error(w, "rate cache not yet populated", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// errUpstreamStale marks a refresh attempt that only succeeded by serving a
+// stale cache entry (see getRates) as a failure, so the background refresh
+// loop keeps retrying with backoff instead of treating the outage as
+// resolved.
+var errUpstreamStale = errors.New("refresh served stale cache, upstream still failing")
+
+// refreshBases lists the base currencies proactively kept warm in
+// latestCache, so that /api/all-rate and /api/convert requests almost never
+// block on the upstream call.
+var refreshBases = []string{"USD"}
+
+// startRefreshLoop refreshes latestCache for each of refreshBases every
+// cacheTTL, with a small random jitter to avoid a thundering herd against
+// Frankfurter, until ctx is done. Failures are retried with exponential
+// backoff rather than waiting for the next tick.
+func startRefreshLoop(ctx context.Context) {
+	refreshAll(ctx)
+
+	ticker := time.NewTicker(cacheTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			select {
+			case <-time.After(jitter(cacheTTL)):
+			case <-ctx.Done():
+				return
+			}
+			refreshAll(ctx)
+		}
+	}
+}
+
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(d) / 10))
+}
+
+func refreshAll(ctx context.Context) {
+	for _, base := range refreshBases {
+		refreshOne(ctx, base)
+	}
+}
+
+func refreshOne(ctx context.Context, base string) {
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = cacheTTL
+
+	err := backoff.Retry(func() error {
+		_, _, stale, err := getRates(ctx, base)
+		if err != nil {
+			return err
+		}
+		if stale {
+			return errUpstreamStale
+		}
+		return nil
+	}, backoff.WithContext(b, ctx))
+
+	if err != nil {
+		logger.Warn("background refresh giving up", "base", base, "error", err)
+	}
+}